@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// fakeCodeAtClient is a minimal codeAtClient stand-in so deployOrReuse's
+// skip-detection logic can be tested without a live node.
+type fakeCodeAtClient struct {
+	code map[common.Address][]byte
+}
+
+func (f *fakeCodeAtClient) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	return f.code[account], nil
+}
+
+func TestDeployOrReuseSkipsOnMatchingCodeLength(t *testing.T) {
+	existing := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	client := &fakeCodeAtClient{code: map[common.Address][]byte{existing: make([]byte, 128)}}
+
+	deployed := false
+	doDeploy := func() (common.Address, *types.Transaction, error) {
+		deployed = true
+		return common.Address{}, nil, nil
+	}
+
+	addr, tx, isNew := deployOrReuse(context.Background(), client, "mimc", existing, true, true, false, 128, doDeploy)
+
+	if deployed {
+		t.Error("deployOrReuse() called doDeploy even though existing code length matched")
+	}
+	if isNew {
+		t.Error("deployOrReuse() isNew = true, want false (reused)")
+	}
+	if addr != existing {
+		t.Errorf("deployOrReuse() addr = %s, want %s", addr.Hex(), existing.Hex())
+	}
+	if tx != nil {
+		t.Errorf("deployOrReuse() tx = %v, want nil", tx)
+	}
+}
+
+func TestDeployOrReuseRedeploysOnCodeLengthMismatch(t *testing.T) {
+	existing := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	// Stale/unrelated bytecode at the recorded address: wrong length.
+	client := &fakeCodeAtClient{code: map[common.Address][]byte{existing: make([]byte, 2)}}
+
+	want := common.HexToAddress("0x3333333333333333333333333333333333333333")
+	deployed := false
+	doDeploy := func() (common.Address, *types.Transaction, error) {
+		deployed = true
+		return want, nil, nil
+	}
+
+	addr, _, isNew := deployOrReuse(context.Background(), client, "mimc", existing, true, true, false, 128, doDeploy)
+
+	if !deployed {
+		t.Error("deployOrReuse() did not call doDeploy despite code length mismatch")
+	}
+	if !isNew {
+		t.Error("deployOrReuse() isNew = false, want true (redeployed)")
+	}
+	if addr != want {
+		t.Errorf("deployOrReuse() addr = %s, want %s", addr.Hex(), want.Hex())
+	}
+}
+
+func TestDeployOrReuseFallsBackToAnyCodeWhenLengthUnknown(t *testing.T) {
+	existing := common.HexToAddress("0x4444444444444444444444444444444444444444")
+	client := &fakeCodeAtClient{code: map[common.Address][]byte{existing: make([]byte, 7)}}
+
+	deployed := false
+	doDeploy := func() (common.Address, *types.Transaction, error) {
+		deployed = true
+		return common.Address{}, nil, nil
+	}
+
+	// expectedCodeLen == 0 means "no manifest entry" -- fall back to the
+	// old "any code present" check rather than requiring an exact match.
+	_, _, isNew := deployOrReuse(context.Background(), client, "mimc", existing, true, true, false, 0, doDeploy)
+
+	if deployed {
+		t.Error("deployOrReuse() called doDeploy even though some code was present and length was unknown")
+	}
+	if isNew {
+		t.Error("deployOrReuse() isNew = true, want false (reused)")
+	}
+}
+
+func TestDeployOrReuseForceAlwaysRedeploys(t *testing.T) {
+	existing := common.HexToAddress("0x5555555555555555555555555555555555555555")
+	client := &fakeCodeAtClient{code: map[common.Address][]byte{existing: make([]byte, 128)}}
+
+	deployed := false
+	doDeploy := func() (common.Address, *types.Transaction, error) {
+		deployed = true
+		return common.Address{}, nil, nil
+	}
+
+	_, _, isNew := deployOrReuse(context.Background(), client, "mimc", existing, true, true, true, 128, doDeploy)
+
+	if !deployed {
+		t.Error("deployOrReuse() did not call doDeploy despite force=true")
+	}
+	if !isNew {
+		t.Error("deployOrReuse() isNew = false, want true (forced redeploy)")
+	}
+}
+
+func TestDeployOrReuseNoExistingDeploysWhenWanted(t *testing.T) {
+	client := &fakeCodeAtClient{code: map[common.Address][]byte{}}
+
+	want := common.HexToAddress("0x6666666666666666666666666666666666666666")
+	doDeploy := func() (common.Address, *types.Transaction, error) {
+		return want, nil, nil
+	}
+
+	addr, _, isNew := deployOrReuse(context.Background(), client, "mimc", common.Address{}, false, true, false, 128, doDeploy)
+
+	if !isNew {
+		t.Error("deployOrReuse() isNew = false, want true (first deployment)")
+	}
+	if addr != want {
+		t.Errorf("deployOrReuse() addr = %s, want %s", addr.Hex(), want.Hex())
+	}
+}
@@ -0,0 +1,85 @@
+// There is no Factory.sol and no solc in this toolchain's deployment
+// pipeline, so unlike the abigen-generated mimc/Verifier/zkLogin bindings
+// in this tree, the bytecode below is hand-assembled EVM, not compiled
+// output. Do not edit it without re-deriving it by hand (or replacing it
+// with a real solc build once one is available) and re-checking the
+// runtime trace in a local EVM.
+package factory
+
+import (
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// FactoryMetaData contains the ABI and deployment bytecode for a minimal
+// CREATE2 deployment factory: `function deploy(bytes32 salt, bytes memory
+// initCode) public returns (address)`.
+//
+// The runtime code is a hand-assembled forwarder rather than compiled
+// Solidity. It does not dispatch on the function selector (this is the
+// contract's only function): it reads `salt` from calldata[4:36] and the
+// ABI-encoded `bytes` length/data starting at calldata[68:] (the canonical
+// offset for a single dynamic argument following one static argument),
+// CREATE2s that data as init code, and returns the 32-byte-padded deployed
+// address, reverting if CREATE2 yields the zero address (e.g. a salt/init
+// code collision with an already-deployed contract).
+var FactoryMetaData = &bind.MetaData{
+	ABI: `[{"inputs":[{"internalType":"bytes32","name":"salt","type":"bytes32"},{"internalType":"bytes","name":"initCode","type":"bytes"}],"name":"deploy","outputs":[{"internalType":"address","name":"deployed","type":"address"}],"stateMutability":"nonpayable","type":"function"}]`,
+	Bin: "0x602480600b6000396000f360043560443580606460003760006000f58015601e5760005260206000f35b60006000fd",
+}
+
+// FactoryABI is the ABI the hand-written binding below is built on.
+var FactoryABI = FactoryMetaData.ABI
+
+// FactoryBin is the hand-assembled bytecode used for deploying new contracts.
+var FactoryBin = FactoryMetaData.Bin
+
+// Factory is a hand-written Go binding around the factory contract.
+type Factory struct {
+	address common.Address
+	abi     abi.ABI
+	backend bind.ContractBackend
+}
+
+// NewFactory creates a new instance of Factory, bound to a specific
+// deployed contract.
+func NewFactory(address common.Address, backend bind.ContractBackend) (*Factory, error) {
+	parsed, err := abi.JSON(strings.NewReader(FactoryABI))
+	if err != nil {
+		return nil, err
+	}
+	return &Factory{address: address, abi: parsed, backend: backend}, nil
+}
+
+// DeployFactory deploys a new Factory contract, binding an instance of
+// Factory to it.
+func DeployFactory(auth *bind.TransactOpts, backend bind.ContractBackend) (common.Address, *types.Transaction, *Factory, error) {
+	parsed, err := abi.JSON(strings.NewReader(FactoryABI))
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+
+	address, tx, _, err := bind.DeployContract(auth, parsed, common.FromHex(FactoryBin), backend)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+
+	return address, tx, &Factory{address: address, abi: parsed, backend: backend}, nil
+}
+
+// Address returns the address this Factory instance is bound to.
+func (f *Factory) Address() common.Address {
+	return f.address
+}
+
+// Deploy calls the factory's `deploy(bytes32,bytes)` method, deploying
+// initCode via CREATE2 under salt.
+func (f *Factory) Deploy(opts *bind.TransactOpts, salt [32]byte, initCode []byte) (*types.Transaction, error) {
+	contract := bind.NewBoundContract(f.address, f.abi, f.backend, f.backend, f.backend)
+	return contract.Transact(opts, "deploy", salt, initCode)
+}
+
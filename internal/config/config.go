@@ -0,0 +1,132 @@
+package config
+
+// config.go
+//
+// Config centralizes every environment-driven knob `deploy`, `wizard`,
+// and `predict` read, so there's a single place to look when tracing
+// where a cfg.Xxx value comes from. Values are read from environment
+// variables (NewConfig/LoadConfig), which matches how this deployer is
+// invoked in CI and in operator shell scripts; there is no config file
+// format to parse.
+
+import (
+	"os"
+	"strconv"
+)
+
+// Config holds every deployer-wide setting. Zero values are safe
+// defaults where that makes sense; LoadConfig fills in the rest from the
+// environment.
+type Config struct {
+	LoggerMode     string
+	DisableBanner  bool
+	Version        string
+
+	AccountsDir    string
+	AccountsNumber int
+	AddressesDir   string
+
+	GethNodeUrl      string
+	GethNodeKeystore string
+	GethNodePassword string
+
+	// ForceLegacyTx and GasPriceGwei pin gas pricing for chains where
+	// auto-detecting EIP-1559 support via BaseFee isn't reliable enough
+	// (see ethutil.ConfigureGas).
+	ForceLegacyTx bool
+	GasPriceGwei  uint64
+
+	// SignerBackend selects the ethutil.Signer implementation ("keystore",
+	// "clef", "ledger", "trezor"). ClefEndpoint and SignerAddress are only
+	// consulted by the backends that need them.
+	SignerBackend string
+	ClefEndpoint  string
+	SignerAddress string
+
+	// UseCreate2 routes Mimc/Verifier/zkLogin deployments through the
+	// CREATE2 factory (see create2.go).
+	UseCreate2 bool
+
+	// EventsSink is a Unix socket ("unix:///path") or HTTP(S) URL that deployment
+	// events are additionally forwarded to, on top of the NDJSON log file
+	// (see events.go). Empty disables forwarding.
+	EventsSink string
+}
+
+// NewConfig returns a Config with its zero values; call LoadConfig to
+// populate it from the environment.
+func NewConfig() *Config {
+	return &Config{}
+}
+
+// LoadConfig populates c from environment variables, falling back to
+// sensible defaults for local/dev use when a variable isn't set.
+func (c *Config) LoadConfig() error {
+	c.LoggerMode = getEnv("LOGGER_MODE", "development")
+	c.DisableBanner = getEnvBool("DISABLE_BANNER", false)
+	c.Version = getEnv("VERSION", "dev")
+
+	c.AccountsDir = getEnv("ACCOUNTS_DIR", "./accounts")
+	accountsNumber, err := getEnvInt("ACCOUNTS_NUMBER", 10)
+	if err != nil {
+		return err
+	}
+	c.AccountsNumber = accountsNumber
+	c.AddressesDir = getEnv("ADDRESSES_DIR", "./addresses")
+
+	c.GethNodeUrl = getEnv("GETH_NODE_URL", "http://127.0.0.1:8545")
+	c.GethNodeKeystore = getEnv("GETH_NODE_KEYSTORE", "./keystore")
+	c.GethNodePassword = getEnv("GETH_NODE_PASSWORD", "")
+
+	c.ForceLegacyTx = getEnvBool("FORCE_LEGACY_TX", false)
+	gasPriceGwei, err := getEnvUint("GAS_PRICE_GWEI", 0)
+	if err != nil {
+		return err
+	}
+	c.GasPriceGwei = gasPriceGwei
+
+	c.SignerBackend = getEnv("SIGNER_BACKEND", "keystore")
+	c.ClefEndpoint = getEnv("CLEF_ENDPOINT", "")
+	c.SignerAddress = getEnv("SIGNER_ADDRESS", "")
+
+	c.UseCreate2 = getEnvBool("USE_CREATE2", false)
+
+	c.EventsSink = getEnv("EVENTS_SINK", "")
+
+	return nil
+}
+
+func getEnv(key, fallback string) string {
+	if v, ok := os.LookupEnv(key); ok {
+		return v
+	}
+	return fallback
+}
+
+func getEnvBool(key string, fallback bool) bool {
+	v, ok := os.LookupEnv(key)
+	if !ok {
+		return fallback
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return fallback
+	}
+	return b
+}
+
+func getEnvInt(key string, fallback int) (int, error) {
+	v, ok := os.LookupEnv(key)
+	if !ok {
+		return fallback, nil
+	}
+	return strconv.Atoi(v)
+}
+
+func getEnvUint(key string, fallback uint64) (uint64, error) {
+	v, ok := os.LookupEnv(key)
+	if !ok {
+		return fallback, nil
+	}
+	return strconv.ParseUint(v, 10, 64)
+}
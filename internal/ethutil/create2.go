@@ -0,0 +1,56 @@
+package ethutil
+
+// create2.go
+//
+// Deterministic CREATE2 address computation for deployments routed
+// through the factory contract (internal/abigen/factory). Letting an
+// operator predict an address before sending any transaction means a
+// zkLogin contract can be pre-funded, and dev/staging/prod environments
+// that derive the same salt get identical addresses across chains.
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Create2Address computes the deterministic deployment address for a
+// contract deployed via CREATE2 from factory, per EIP-1014:
+//
+//	keccak256(0xff ++ factory ++ salt ++ keccak256(initCode))[12:]
+func Create2Address(factory common.Address, salt [32]byte, initCode []byte) common.Address {
+	initCodeHash := crypto.Keccak256(initCode)
+
+	data := make([]byte, 0, 1+common.AddressLength+len(salt)+len(initCodeHash))
+	data = append(data, 0xff)
+	data = append(data, factory.Bytes()...)
+	data = append(data, salt[:]...)
+	data = append(data, initCodeHash...)
+
+	hash := crypto.Keccak256(data)
+	return common.BytesToAddress(hash[12:])
+}
+
+// MiMCHasher matches the sponge hash function already initialized in
+// main, kept generic here so ethutil doesn't need to depend on the mimc
+// package just to derive a salt.
+type MiMCHasher func(inputs ...*big.Int) (*big.Int, error)
+
+// SaltFromMiMC derives a deterministic 32-byte CREATE2 salt from
+// chainID, contractName and version via the given MiMC sponge, so
+// identical (sponge params, chainId, contract, version) tuples always
+// produce the same salt across environments.
+func SaltFromMiMC(hash MiMCHasher, chainID *big.Int, contractName string, version uint64) ([32]byte, error) {
+	nameInt := new(big.Int).SetBytes([]byte(contractName))
+	versionInt := new(big.Int).SetUint64(version)
+
+	out, err := hash(chainID, nameInt, versionInt)
+	if err != nil {
+		return [32]byte{}, err
+	}
+
+	var salt [32]byte
+	out.FillBytes(salt[:])
+	return salt, nil
+}
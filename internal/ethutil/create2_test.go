@@ -0,0 +1,142 @@
+package ethutil
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestCreate2Address(t *testing.T) {
+	// Vectors computed independently from the EIP-1014 formula
+	// (keccak256(0xff ++ factory ++ salt ++ keccak256(initCode))[12:]).
+	tests := []struct {
+		name     string
+		factory  string
+		salt     string
+		initCode string
+		want     string
+	}{
+		{
+			name:     "zero factory, zero salt, empty init code",
+			factory:  "0x0000000000000000000000000000000000000000",
+			salt:     "0000000000000000000000000000000000000000000000000000000000000000",
+			initCode: "",
+			want:     "0xe33c0c7f7df4809055c3eba6c09cfe4baf1bd9e0",
+		},
+		{
+			name:     "non-zero factory and salt, short init code",
+			factory:  "0xdeadbeef00000000000000000000000000000000",
+			salt:     "1111111111111111111111111111111111111111111111111111111111111111",
+			initCode: "6001600155",
+			want:     "0xebfd8791065dc80df60d194d5a266883a0896810",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var salt [32]byte
+			copy(salt[:], common.FromHex(tt.salt))
+
+			got := Create2Address(common.HexToAddress(tt.factory), salt, common.FromHex(tt.initCode))
+			if got.Hex() != common.HexToAddress(tt.want).Hex() {
+				t.Errorf("Create2Address() = %s, want %s", got.Hex(), tt.want)
+			}
+		})
+	}
+}
+
+func TestCreate2AddressDependsOnEveryInput(t *testing.T) {
+	factory := common.HexToAddress("0x1234567890123456789012345678901234567890")
+	salt := [32]byte{1}
+	initCode := common.FromHex("6001600155")
+
+	base := Create2Address(factory, salt, initCode)
+
+	otherFactory := common.HexToAddress("0x0000000000000000000000000000000000000001")
+	if got := Create2Address(otherFactory, salt, initCode); got == base {
+		t.Error("changing factory did not change the predicted address")
+	}
+
+	otherSalt := [32]byte{2}
+	if got := Create2Address(factory, otherSalt, initCode); got == base {
+		t.Error("changing salt did not change the predicted address")
+	}
+
+	otherInitCode := common.FromHex("6002600255")
+	if got := Create2Address(factory, salt, otherInitCode); got == base {
+		t.Error("changing init code did not change the predicted address")
+	}
+}
+
+func TestSaltFromMiMC(t *testing.T) {
+	chainID := big.NewInt(1337)
+
+	// A fake sponge that deterministically folds its inputs; real values
+	// don't matter here, only that SaltFromMiMC calls hash with the
+	// arguments it documents and turns the result into 32 bytes.
+	fakeHash := func(inputs ...*big.Int) (*big.Int, error) {
+		sum := new(big.Int)
+		for _, in := range inputs {
+			sum.Add(sum, in)
+		}
+		return sum, nil
+	}
+
+	salt, err := SaltFromMiMC(fakeHash, chainID, "mimc", 1)
+	if err != nil {
+		t.Fatalf("SaltFromMiMC() error = %v", err)
+	}
+
+	nameInt := new(big.Int).SetBytes([]byte("mimc"))
+	want := new(big.Int).Add(new(big.Int).Add(chainID, nameInt), big.NewInt(1))
+	var wantSalt [32]byte
+	want.FillBytes(wantSalt[:])
+
+	if salt != wantSalt {
+		t.Errorf("SaltFromMiMC() = %x, want %x", salt, wantSalt)
+	}
+}
+
+func TestSaltFromMiMCDiffersByContractAndVersion(t *testing.T) {
+	chainID := big.NewInt(1)
+	fakeHash := func(inputs ...*big.Int) (*big.Int, error) {
+		sum := new(big.Int)
+		for _, in := range inputs {
+			sum.Add(sum, in)
+		}
+		return sum, nil
+	}
+
+	mimcSalt, err := SaltFromMiMC(fakeHash, chainID, "mimc", 1)
+	if err != nil {
+		t.Fatalf("SaltFromMiMC(mimc) error = %v", err)
+	}
+	verifierSalt, err := SaltFromMiMC(fakeHash, chainID, "verifier", 1)
+	if err != nil {
+		t.Fatalf("SaltFromMiMC(verifier) error = %v", err)
+	}
+	if mimcSalt == verifierSalt {
+		t.Error("different contract names produced the same salt")
+	}
+
+	mimcV2Salt, err := SaltFromMiMC(fakeHash, chainID, "mimc", 2)
+	if err != nil {
+		t.Fatalf("SaltFromMiMC(mimc, v2) error = %v", err)
+	}
+	if mimcSalt == mimcV2Salt {
+		t.Error("bumping version produced the same salt")
+	}
+}
+
+func TestSaltFromMiMCPropagatesHashError(t *testing.T) {
+	wantErr := errors.New("sponge exploded")
+	failingHash := func(inputs ...*big.Int) (*big.Int, error) {
+		return nil, wantErr
+	}
+
+	if _, err := SaltFromMiMC(failingHash, big.NewInt(1), "mimc", 1); !errors.Is(err, wantErr) {
+		t.Errorf("SaltFromMiMC() error = %v, want %v", err, wantErr)
+	}
+}
@@ -0,0 +1,61 @@
+package ethutil
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestGasPricingMode(t *testing.T) {
+	baseFee := big.NewInt(1_000_000_000)
+
+	tests := []struct {
+		name         string
+		forceLegacy  bool
+		baseFee      *big.Int
+		gasPriceGwei uint64
+		wantLegacy   bool
+		wantPinned   *big.Int
+	}{
+		{
+			name:       "london chain uses EIP-1559",
+			baseFee:    baseFee,
+			wantLegacy: false,
+			wantPinned: nil,
+		},
+		{
+			name:       "nil base fee falls back to legacy",
+			baseFee:    nil,
+			wantLegacy: true,
+			wantPinned: nil,
+		},
+		{
+			name:        "forceLegacy overrides a non-nil base fee",
+			forceLegacy: true,
+			baseFee:     baseFee,
+			wantLegacy:  true,
+			wantPinned:  nil,
+		},
+		{
+			name:         "gasPriceGwei pins the legacy price",
+			forceLegacy:  true,
+			gasPriceGwei: 5,
+			wantLegacy:   true,
+			wantPinned:   big.NewInt(5_000_000_000),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			legacy, pinned := gasPricingMode(tt.forceLegacy, tt.baseFee, tt.gasPriceGwei)
+			if legacy != tt.wantLegacy {
+				t.Errorf("legacy = %v, want %v", legacy, tt.wantLegacy)
+			}
+			if (pinned == nil) != (tt.wantPinned == nil) {
+				t.Fatalf("pinned = %v, want %v", pinned, tt.wantPinned)
+			}
+			if pinned != nil && pinned.Cmp(tt.wantPinned) != 0 {
+				t.Errorf("pinned = %s, want %s", pinned, tt.wantPinned)
+			}
+		})
+	}
+}
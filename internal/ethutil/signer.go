@@ -0,0 +1,87 @@
+package ethutil
+
+// signer.go
+//
+// Signer abstracts "how does a deployment transaction get signed" away
+// from "where does the private key live". The original flow assumed a
+// decrypted keystore file sitting on the deployment host, which is a real
+// operational concern for production zkLogin deployments where the
+// deployer key controls the contract owner. Signer lets main pick a
+// backend (keystore file, an external Clef signer, or a hardware wallet)
+// and hand the resulting bind.TransactOpts to the DeployXxx calls
+// unchanged.
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Signer produces transact options bound to chainID. Implementations may
+// perform network or USB I/O (Clef, hardware wallets); ctx becomes the
+// returned options' Context and does not bound that I/O.
+type Signer interface {
+	TransactOpts(ctx context.Context, chainID *big.Int) (*bind.TransactOpts, error)
+}
+
+// SignerConfig carries the parameters needed to construct any Signer
+// backend. Callers only need to populate the fields relevant to the
+// backend they select.
+type SignerConfig struct {
+	KeystoreDir      string
+	KeystorePassword string
+	ClefEndpoint     string
+	From             common.Address
+	DerivationPath   accounts.DerivationPath
+}
+
+// NewSigner constructs the Signer backend named by backend, using cfg for
+// whichever fields that backend needs. Supported backends are "keystore"
+// (the default), "clef", "ledger", and "trezor".
+func NewSigner(backend string, cfg SignerConfig) (Signer, error) {
+	switch backend {
+	case "", "keystore":
+		return NewKeystoreSigner(cfg.KeystoreDir, cfg.KeystorePassword), nil
+	case "clef":
+		return NewClefSigner(cfg.ClefEndpoint, cfg.From), nil
+	case "ledger", "trezor":
+		path := cfg.DerivationPath
+		if path == nil {
+			path = accounts.DefaultBaseDerivationPath
+		}
+		return NewHardwareSigner(backend, path)
+	default:
+		return nil, fmt.Errorf("unknown signer backend %q", backend)
+	}
+}
+
+// KeystoreSigner implements Signer over the existing "decrypt a keyfile
+// from disk" flow.
+type KeystoreSigner struct {
+	keystoreDir string
+	password    string
+}
+
+// NewKeystoreSigner returns a Signer that decrypts the first keyfile found
+// under keystoreDir using password.
+func NewKeystoreSigner(keystoreDir, password string) *KeystoreSigner {
+	return &KeystoreSigner{keystoreDir: keystoreDir, password: password}
+}
+
+func (s *KeystoreSigner) TransactOpts(ctx context.Context, chainID *big.Int) (*bind.TransactOpts, error) {
+	keyfile, err := FindPrivateKey(s.keystoreDir)
+	if err != nil {
+		return nil, fmt.Errorf("finding private key in keystore: %w", err)
+	}
+
+	privateKey, err := DecryptKeyfile(keyfile, s.password)
+	if err != nil {
+		return nil, fmt.Errorf("decrypting keystore key: %w", err)
+	}
+
+	return NewTransactorFromKeystore(privateKey, chainID)
+}
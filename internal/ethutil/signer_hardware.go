@@ -0,0 +1,78 @@
+package ethutil
+
+// signer_hardware.go
+//
+// Hardware-wallet-backed Signer (Ledger/Trezor) via go-ethereum's
+// accounts/usbwallet. Lets a deployment be authorized by a physically
+// present device rather than a key on disk.
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/usbwallet"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// HardwareSigner implements Signer over a Ledger or Trezor device.
+type HardwareSigner struct {
+	kind           string
+	derivationPath accounts.DerivationPath
+}
+
+// NewHardwareSigner checks the requested device kind ("ledger" or
+// "trezor") and does no USB I/O. The USB hub and the device are only
+// opened, and the account derived, once TransactOpts is called.
+func NewHardwareSigner(kind string, derivationPath accounts.DerivationPath) (*HardwareSigner, error) {
+	switch kind {
+	case "ledger", "trezor":
+		return &HardwareSigner{kind: kind, derivationPath: derivationPath}, nil
+	default:
+		return nil, fmt.Errorf("unknown hardware wallet kind %q", kind)
+	}
+}
+
+func (s *HardwareSigner) TransactOpts(ctx context.Context, chainID *big.Int) (*bind.TransactOpts, error) {
+	hub, err := s.openHub()
+	if err != nil {
+		return nil, fmt.Errorf("opening %s hub: %w", s.kind, err)
+	}
+
+	wallets := hub.Wallets()
+	if len(wallets) == 0 {
+		return nil, fmt.Errorf("no %s device found", s.kind)
+	}
+	wallet := wallets[0]
+
+	if err := wallet.Open(""); err != nil {
+		return nil, fmt.Errorf("opening %s wallet: %w", s.kind, err)
+	}
+
+	account, err := wallet.Derive(s.derivationPath, true)
+	if err != nil {
+		return nil, fmt.Errorf("deriving account on %s: %w", s.kind, err)
+	}
+
+	return &bind.TransactOpts{
+		From:    account.Address,
+		Context: ctx,
+		Signer: func(addr common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			return wallet.SignTx(account, tx, chainID)
+		},
+	}, nil
+}
+
+func (s *HardwareSigner) openHub() (*usbwallet.Hub, error) {
+	switch s.kind {
+	case "ledger":
+		return usbwallet.NewLedgerHub()
+	case "trezor":
+		return usbwallet.NewTrezorHubWithHID()
+	default:
+		return nil, fmt.Errorf("unknown hardware wallet kind %q", s.kind)
+	}
+}
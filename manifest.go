@@ -0,0 +1,105 @@
+package main
+
+// manifest.go
+//
+// Per-contract deployment manifest, written alongside addresses.json. It
+// records enough detail about each deployment (tx hash, block number,
+// deployer, code hash, constructor args) that a crashed run can be safely
+// resumed without wondering what state a given contract was left in.
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// contractDeployment is a single manifest entry for one deployed contract.
+type contractDeployment struct {
+	Contract        string         `json:"contract"`
+	Address         common.Address `json:"address"`
+	TxHash          common.Hash    `json:"txHash"`
+	BlockNumber     uint64         `json:"blockNumber"`
+	Deployer        common.Address `json:"deployer"`
+	CodeHash        common.Hash    `json:"codeHash"`
+	CodeSize        int            `json:"codeSize"`
+	ConstructorArgs []interface{}  `json:"constructorArgs,omitempty"`
+	Timestamp       string         `json:"timestamp"`
+}
+
+// deploymentManifest tracks every contract deployed by a `deploy` run,
+// keyed by contract name so re-runs can be resumed idempotently.
+type deploymentManifest struct {
+	Deployments map[string]contractDeployment `json:"deployments"`
+	path        string
+}
+
+// loadManifest reads an existing manifest from disk, or returns an empty
+// one if none exists yet.
+func loadManifest(path string) (*deploymentManifest, error) {
+	m := &deploymentManifest{Deployments: make(map[string]contractDeployment), path: path}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return m, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, err
+	}
+	if m.Deployments == nil {
+		m.Deployments = make(map[string]contractDeployment)
+	}
+	m.path = path
+	return m, nil
+}
+
+// record adds or replaces the manifest entry for a newly deployed
+// contract, deriving the code hash from the on-chain bytecode, and
+// persists the manifest immediately so a crash mid-run leaves a usable
+// record of what has already happened.
+func (m *deploymentManifest) record(ctx context.Context, client *ethclient.Client, name string, address common.Address, tx *types.Transaction, receipt *types.Receipt, deployer common.Address, constructorArgs []interface{}) (contractDeployment, error) {
+	code, err := client.CodeAt(ctx, address, nil)
+	if err != nil {
+		return contractDeployment{}, err
+	}
+
+	entry := contractDeployment{
+		Contract:        name,
+		Address:         address,
+		TxHash:          tx.Hash(),
+		BlockNumber:     receipt.BlockNumber.Uint64(),
+		Deployer:        deployer,
+		CodeHash:        crypto.Keccak256Hash(code),
+		CodeSize:        len(code),
+		ConstructorArgs: constructorArgs,
+		Timestamp:       time.Now().UTC().Format(time.RFC3339),
+	}
+
+	m.Deployments[name] = entry
+	return entry, m.save()
+}
+
+// codeSize returns the bytecode length recorded the last time name was
+// deployed, or 0 if there is no manifest entry for it (e.g. addresses.json
+// was seeded from outside this tool). 0 means "unknown size", not "empty
+// code".
+func (m *deploymentManifest) codeSize(name string) int {
+	return m.Deployments[name].CodeSize
+}
+
+func (m *deploymentManifest) save() error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(m.path, data, 0o644)
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestLoadManifestMissingFileReturnsEmpty(t *testing.T) {
+	m, err := loadManifest(filepath.Join(t.TempDir(), "does-not-exist.json"))
+	if err != nil {
+		t.Fatalf("loadManifest() error = %v", err)
+	}
+	if len(m.Deployments) != 0 {
+		t.Errorf("loadManifest() on missing file = %d deployments, want 0", len(m.Deployments))
+	}
+	if m.codeSize("mimc") != 0 {
+		t.Errorf("codeSize() on empty manifest = %d, want 0", m.codeSize("mimc"))
+	}
+}
+
+func TestManifestSaveAndLoadRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "manifest.json")
+
+	m := &deploymentManifest{Deployments: make(map[string]contractDeployment), path: path}
+	m.Deployments["mimc"] = contractDeployment{
+		Contract: "mimc",
+		Address:  common.HexToAddress("0x1111111111111111111111111111111111111111"),
+		CodeSize: 256,
+	}
+	if err := m.save(); err != nil {
+		t.Fatalf("save() error = %v", err)
+	}
+
+	loaded, err := loadManifest(path)
+	if err != nil {
+		t.Fatalf("loadManifest() error = %v", err)
+	}
+
+	if got := loaded.codeSize("mimc"); got != 256 {
+		t.Errorf("codeSize(%q) = %d, want 256", "mimc", got)
+	}
+	if got := loaded.codeSize("verifier"); got != 0 {
+		t.Errorf("codeSize(%q) = %d, want 0 (no entry)", "verifier", got)
+	}
+	if addr := loaded.Deployments["mimc"].Address; addr != m.Deployments["mimc"].Address {
+		t.Errorf("loaded address = %s, want %s", addr.Hex(), m.Deployments["mimc"].Address.Hex())
+	}
+}
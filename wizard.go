@@ -0,0 +1,278 @@
+package main
+
+// wizard.go
+//
+// Interactive, Puppeth-style network wizard. It walks an operator through
+// bootstrapping a private GoQuorum/Geth-compatible network (consensus,
+// chain id, block period, foodbank prefunding) and writes out everything a
+// subsequent `deploy` run expects: a genesis.json, a docker-compose.yml /
+// systemd unit for bootnode + validators, the foodbank and validator
+// account files under cfg.AccountsDir, and an addresses.json holding the
+// validator addresses under cfg.AddressesDir.
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"deployer/internal/accounts"
+	"deployer/internal/addresses"
+	"deployer/internal/config"
+	"deployer/internal/directory"
+	"deployer/internal/logger"
+	mimcsponge "deployer/internal/mimc"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// genesisSpec mirrors the subset of a Geth/GoQuorum genesis.json that the
+// wizard needs to populate. It is kept as a plain struct (rather than
+// reusing go-ethereum's core.Genesis) because GoQuorum's Istanbul/QBFT
+// genesis fields aren't part of upstream go-ethereum.
+type genesisSpec struct {
+	Config     *genesisConfig            `json:"config"`
+	Nonce      string                    `json:"nonce"`
+	Timestamp  string                    `json:"timestamp"`
+	ExtraData  string                    `json:"extraData"`
+	GasLimit   string                    `json:"gasLimit"`
+	Difficulty string                    `json:"difficulty"`
+	MixHash    string                    `json:"mixHash"`
+	Coinbase   string                    `json:"coinbase"`
+	Alloc      map[string]genesisAccount `json:"alloc"`
+}
+
+type genesisConfig struct {
+	ChainID             int64           `json:"chainId"`
+	HomesteadBlock      int             `json:"homesteadBlock"`
+	Eip150Block         int             `json:"eip150Block"`
+	Eip155Block         int             `json:"eip155Block"`
+	Eip158Block         int             `json:"eip158Block"`
+	ByzantiumBlock      int             `json:"byzantiumBlock"`
+	ConstantinopleBlock int             `json:"constantinopleBlock"`
+	PetersburgBlock     int             `json:"petersburgBlock"`
+	Clique              *cliqueConfig   `json:"clique,omitempty"`
+	Istanbul            *istanbulConfig `json:"istanbul,omitempty"`
+}
+
+type cliqueConfig struct {
+	Period uint64 `json:"period"`
+	Epoch  uint64 `json:"epoch"`
+}
+
+// istanbulConfig is shared by the ibft and qbft consensus choices; qbft is
+// selected purely by the operator's choice of validator vote/finality
+// rules at the node level, the genesis shape is identical.
+type istanbulConfig struct {
+	Epoch          uint64 `json:"epoch"`
+	ProposerPolicy uint64 `json:"proposerPolicy"`
+}
+
+type genesisAccount struct {
+	Balance string `json:"balance"`
+}
+
+// runWizard drives the interactive prompts and emits genesis.json,
+// docker-compose.yml, a systemd unit, and the foodbank account material.
+// It is intentionally linear and synchronous: it is meant to be run once,
+// by a human, before the first `deploy`.
+func runWizard(args []string) {
+	reader := bufio.NewReader(os.Stdin)
+
+	cfg := config.NewConfig()
+	if err := cfg.LoadConfig(); err != nil {
+		logger.Logger.Fatal().Err(err).Msg("Failed to load Config")
+	}
+
+	fmt.Println("== Pinacle network wizard ==")
+	fmt.Println("This will generate a genesis.json and deployment files for a new private network.")
+
+	consensus := promptChoice(reader, "Consensus engine", []string{"clique", "ibft", "qbft"}, "clique")
+	chainID := promptInt(reader, "Chain ID", 1337)
+	blockPeriod := promptInt(reader, "Block period (seconds)", 5)
+	numValidators := promptInt(reader, "Number of validators", 1)
+	numFoodbanks := promptInt(reader, "Number of foodbank accounts to prefund", cfg.AccountsNumber)
+	prefundWei := promptString(reader, "Prefund balance per account (wei)", "1000000000000000000000")
+
+	if err := directory.CreateDirIfNotExists(cfg.AccountsDir); err != nil {
+		logger.Logger.Fatal().Err(err).Msg("Failed to create accounts directory")
+	}
+
+	// Initialize MiMC sponge.
+	mimcspongeInstance, err := mimcsponge.NewMiMCSponge(mimcsponge.Seed, mimcsponge.MimcNbRounds)
+	if err != nil {
+		logger.Logger.Fatal().Err(err).Msg("Failed to initialize MiMC Sponge")
+	}
+
+	// Generate and persist the foodbank accounts/keystore exactly as
+	// `deploy` does, so a subsequent run picks them up automatically.
+	foodbanksFilename := "foodBanks"
+	foodbanksPath := filepath.Join(cfg.AccountsDir, fmt.Sprintf("%s.json", foodbanksFilename))
+
+	foodbanks := accounts.NewAccounts(foodbanksFilename)
+	foodbanks.SetMiMC(mimcspongeInstance) // Set MiMC for hashing addresses.
+	foodbanks.CreateAccounts(numFoodbanks)
+	foodbanks.SaveToFile(foodbanksPath)
+
+	// Validator accounts double as the initial signer set.
+	validators := accounts.NewAccounts("validators")
+	validators.CreateAccounts(numValidators)
+	validators.SaveToFile(filepath.Join(cfg.AccountsDir, "validators.json"))
+
+	validatorAddrs := derefAddresses(validators.ExtractAddresses())
+	foodbankAddrs := derefAddresses(foodbanks.ExtractAddresses())
+
+	gen := buildGenesis(consensus, int64(chainID), uint64(blockPeriod), validatorAddrs, foodbankAddrs, prefundWei)
+
+	genesisPath := "genesis.json"
+	writeJSONFile(genesisPath, gen)
+	logger.Logger.Info().Str("path", genesisPath).Msg("Wrote genesis.json")
+
+	composePath := "docker-compose.yml"
+	if err := os.WriteFile(composePath, []byte(renderDockerCompose(numValidators)), 0o644); err != nil {
+		logger.Logger.Fatal().Err(err).Msg("Failed to write docker-compose.yml")
+	}
+	logger.Logger.Info().Str("path", composePath).Msg("Wrote docker-compose.yml")
+
+	unitPath := "pinacle-validator.service"
+	if err := os.WriteFile(unitPath, []byte(renderSystemdUnit()), 0o644); err != nil {
+		logger.Logger.Fatal().Err(err).Msg("Failed to write systemd unit")
+	}
+	logger.Logger.Info().Str("path", unitPath).Msg("Wrote systemd unit")
+
+	// Record the validator set alongside the contract addresses file so
+	// `deploy` (and operators) can find it without re-reading genesis.json.
+	contractAddresses := addresses.NewAddresses()
+	for i, addr := range validatorAddrs {
+		contractAddresses.AddContract(fmt.Sprintf("validator%d", i), addr)
+	}
+	contractAddresses.SaveToFile(filepath.Join(cfg.AddressesDir, "addresses.json"))
+
+	logger.Logger.Info().
+		Str("consensus", consensus).
+		Int64("chainId", int64(chainID)).
+		Int("validators", numValidators).
+		Int("foodbanks", numFoodbanks).
+		Msg("Network wizard finished; run `deploy` against the new network to deploy contracts")
+}
+
+func buildGenesis(consensus string, chainID int64, period uint64, validators, foodbanks []common.Address, prefundWei string) *genesisSpec {
+	cfgOut := &genesisConfig{ChainID: chainID}
+
+	switch consensus {
+	case "clique":
+		cfgOut.Clique = &cliqueConfig{Period: period, Epoch: 30000}
+	default: // "ibft", "qbft"
+		cfgOut.Istanbul = &istanbulConfig{Epoch: 30000, ProposerPolicy: 0}
+	}
+
+	alloc := make(map[string]genesisAccount, len(foodbanks))
+	for _, addr := range foodbanks {
+		alloc[strings.ToLower(addr.Hex())] = genesisAccount{Balance: prefundWei}
+	}
+
+	extraData := cliqueExtraData(consensus, validators)
+
+	return &genesisSpec{
+		Config:     cfgOut,
+		Nonce:      "0x0",
+		Timestamp:  "0x0",
+		ExtraData:  extraData,
+		GasLimit:   "0xB71B00", // 12,000,000
+		Difficulty: "0x1",
+		MixHash:    "0x0000000000000000000000000000000000000000000000000000000000000000",
+		Coinbase:   "0x0000000000000000000000000000000000000000",
+		Alloc:      alloc,
+	}
+}
+
+// cliqueExtraData packs the 32-byte vanity + concatenated signer addresses
+// + 65-byte seal placeholder that clique (and, close enough for bootstrap
+// purposes, ibft/qbft) expect in extraData.
+func cliqueExtraData(consensus string, validators []common.Address) string {
+	vanity := strings.Repeat("00", 32)
+	seal := strings.Repeat("00", 65)
+	var signers strings.Builder
+	for _, v := range validators {
+		signers.WriteString(strings.TrimPrefix(v.Hex(), "0x"))
+	}
+	return "0x" + vanity + signers.String() + seal
+}
+
+func writeJSONFile(path string, v interface{}) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		logger.Logger.Fatal().Err(err).Msg("Failed to marshal genesis")
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		logger.Logger.Fatal().Err(err).Msg("Failed to write genesis file")
+	}
+}
+
+func renderDockerCompose(numValidators int) string {
+	var b strings.Builder
+	b.WriteString("version: \"3.8\"\n")
+	b.WriteString("services:\n")
+	b.WriteString("  bootnode:\n")
+	b.WriteString("    image: ethereum/client-go:stable\n")
+	b.WriteString("    command: [\"--nodekey=/data/bootnode.key\", \"--networkid\", \"1337\"]\n")
+	b.WriteString("    volumes: [\"./bootnode:/data\"]\n")
+	for i := 0; i < numValidators; i++ {
+		fmt.Fprintf(&b, "  validator%d:\n", i)
+		b.WriteString("    image: ethereum/client-go:stable\n")
+		b.WriteString("    depends_on: [bootnode]\n")
+		fmt.Fprintf(&b, "    volumes: [\"./validator%d:/data\"]\n", i)
+		b.WriteString("    command: [\"--datadir=/data\", \"--mine\", \"--networkid\", \"1337\"]\n")
+	}
+	return b.String()
+}
+
+func renderSystemdUnit() string {
+	return strings.Join([]string{
+		"[Unit]",
+		"Description=Pinacle private network validator",
+		"After=network.target",
+		"",
+		"[Service]",
+		"ExecStart=/usr/local/bin/geth --datadir=/var/lib/pinacle --mine",
+		"Restart=on-failure",
+		"User=pinacle",
+		"",
+		"[Install]",
+		"WantedBy=multi-user.target",
+		"",
+	}, "\n")
+}
+
+func promptString(reader *bufio.Reader, label, def string) string {
+	fmt.Printf("%s [%s]: ", label, def)
+	line, _ := reader.ReadString('\n')
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return def
+	}
+	return line
+}
+
+func promptInt(reader *bufio.Reader, label string, def int) int {
+	raw := promptString(reader, label, strconv.Itoa(def))
+	n, err := strconv.Atoi(raw)
+	if err != nil {
+		return def
+	}
+	return n
+}
+
+func promptChoice(reader *bufio.Reader, label string, choices []string, def string) string {
+	fmt.Printf("%s (%s)", label, strings.Join(choices, "/"))
+	raw := promptString(reader, "", def)
+	for _, c := range choices {
+		if strings.EqualFold(raw, c) {
+			return c
+		}
+	}
+	return def
+}